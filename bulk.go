@@ -0,0 +1,245 @@
+package mgoHelpers
+
+import (
+	"context"
+
+	"gopkg.in/mgo.v2"
+)
+
+// BulkOptions controls how a bulk write is executed.
+type BulkOptions struct {
+	// Ordered stops the whole bulk operation at the first failing document
+	// when true. When false, every document is attempted regardless of
+	// earlier failures (mgo's Unordered mode).
+	Ordered bool
+	// ContinueOnError, when true, still attempts to insert the entries whose
+	// factory call succeeded even if other entries in the same batch failed
+	// to build. When false (the default), a single factory failure aborts
+	// the whole batch with errBulkOpAborted, as before.
+	ContinueOnError bool
+}
+
+// BulkResult reports the outcome of a bulk write, preserving the
+// per-document outcome instead of collapsing it into a single error.
+type BulkResult struct {
+	// Inserted is the number of documents that were written successfully.
+	Inserted int
+	// Pairs holds one entry per input document, in order, with Error set
+	// for whichever ones failed (either at the factory step or at insert).
+	// When an ordered write stops at a failure, the documents after it are
+	// not written and their pairs carry no Error; Inserted counts only the
+	// documents actually written.
+	Pairs EntryErrorPairs
+}
+
+// pairsForInsert builds an EntryErrorPairs from a factory param set, running
+// each through makeOne. If any factory call fails and opts.ContinueOnError is
+// false, it returns errBulkOpAborted without touching the database, matching
+// the historical all-or-nothing behavior of CreateBulk.
+func (c *MongoCollection) pairsForInsert(factoryFuncParamSet []interface{}, opts BulkOptions) (EntryErrorPairs, error) {
+	var pairs EntryErrorPairs
+	for _, params := range factoryFuncParamSet {
+		entry, eErr := c.makeOne(params)
+		pairs = append(pairs, EntryErrorPair{entry, eErr})
+	}
+
+	if pairs.CheckPartFail() && !opts.ContinueOnError {
+		return pairs, errBulkOpAborted
+	}
+
+	return pairs, nil
+}
+
+// insertPairsCtx inserts the entries in pairs that have no Error set via a
+// single bulk write, recording any per-document insert failures back onto
+// pairs. It returns the number of documents actually written. It fails with
+// errStorageUnsupported if the collection's Storage backend does not
+// implement BulkWriter.
+func (c *MongoCollection) insertPairsCtx(ctx context.Context, pairs EntryErrorPairs, opts BulkOptions) (inserted int, err error) {
+	var docIdx []int
+	var docs []interface{}
+	for i, p := range pairs {
+		if p.Error == nil {
+			docIdx = append(docIdx, i)
+			docs = append(docs, p.Entry)
+		}
+	}
+
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	bulkWriter, ok := c.Database.(BulkWriter)
+	if !ok {
+		return 0, errStorageUnsupported
+	}
+
+	inserted, failures, err := bulkWriter.BulkInsert(ctx, c.Collection, docs, opts)
+	for localIdx, ferr := range failures {
+		pairs[docIdx[localIdx]].Error = ferr
+	}
+
+	return inserted, err
+}
+
+// InsertBulkCtx writes entries in a single bulk operation governed by opts,
+// honoring ctx's deadline/cancellation. Every entry gets its own result in
+// BulkResult.Pairs; a failure in one entry never hides the others.
+func (c *MongoCollection) InsertBulkCtx(ctx context.Context, entries []MongoEntry, opts BulkOptions) (result BulkResult, err error) {
+	pairs := make(EntryErrorPairs, len(entries))
+	for i, e := range entries {
+		pairs[i] = EntryErrorPair{Entry: e}
+	}
+
+	c.MutexExec(func() {
+		result.Inserted, err = c.insertPairsCtx(ctx, pairs, opts)
+	})
+	result.Pairs = pairs
+
+	return result, err
+}
+
+// InsertBulk writes entries in a single ordered, abort-on-error bulk operation.
+func (c *MongoCollection) InsertBulk(entries []MongoEntry) (BulkResult, error) {
+	return c.InsertBulkCtx(context.Background(), entries, BulkOptions{Ordered: true})
+}
+
+// CreateBulkCtx builds entries from factoryFuncParamSet via the collection's
+// factory function and writes them in a single bulk operation governed by
+// opts, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) CreateBulkCtx(ctx context.Context, factoryFuncParamSet []interface{}, opts BulkOptions) (result BulkResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errPanic
+		}
+	}()
+
+	pairs, pairErr := c.pairsForInsert(factoryFuncParamSet, opts)
+	if pairErr != nil {
+		return BulkResult{Pairs: pairs}, pairErr
+	}
+
+	c.MutexExec(func() {
+		result.Inserted, err = c.insertPairsCtx(ctx, pairs, opts)
+	})
+	result.Pairs = pairs
+
+	return result, err
+}
+
+// CreateBulk builds entries from factoryFuncParamSet and writes them in a
+// single ordered, abort-on-error bulk operation, matching the historical
+// behavior of CreateBulk.
+func (c *MongoCollection) CreateBulk(factoryFuncParamSet []interface{}) (BulkResult, error) {
+	return c.CreateBulkCtx(context.Background(), factoryFuncParamSet, BulkOptions{Ordered: true})
+}
+
+// UpsertPair is one (selector, entry) pair for UpsertBulk: entry replaces
+// whichever document matches selector, or is inserted if none does.
+type UpsertPair struct {
+	Selector interface{}
+	Entry    MongoEntry
+}
+
+// UpsertBulkCtx issues a single bulk upsert for pairs, honoring ctx's
+// deadline/cancellation. It fails with errStorageUnsupported if the
+// collection's Storage backend does not implement BulkWriter.
+func (c *MongoCollection) UpsertBulkCtx(ctx context.Context, pairs []UpsertPair, opts BulkOptions) (err error) {
+	bulkWriter, ok := c.Database.(BulkWriter)
+	if !ok {
+		return errStorageUnsupported
+	}
+
+	c.MutexExec(func() {
+		docs := make([]interface{}, 0, len(pairs)*2)
+		for _, p := range pairs {
+			docs = append(docs, p.Selector, p.Entry)
+		}
+
+		err = bulkWriter.BulkUpsert(ctx, c.Collection, docs, opts)
+	})
+
+	return err
+}
+
+// UpsertBulk issues a single ordered bulk upsert for pairs.
+func (c *MongoCollection) UpsertBulk(pairs []UpsertPair) error {
+	return c.UpsertBulkCtx(context.Background(), pairs, BulkOptions{Ordered: true})
+}
+
+// bulkErrorsByIndex extracts the per-document failures out of err, if it is
+// an *mgo.BulkError, keyed by the document's index within the batch passed
+// to Bulk.Insert/Bulk.Upsert. A nil or non-bulk err yields a nil map.
+func bulkErrorsByIndex(err error) map[int]error {
+	bulkErr, ok := err.(*mgo.BulkError)
+	if !ok {
+		return nil
+	}
+
+	failures := make(map[int]error)
+	for _, c := range bulkErr.Cases() {
+		failures[c.Index] = c.Err
+	}
+
+	return failures
+}
+
+// bulkInsertCtx inserts docs via the mgo Bulk API, honoring ctx's
+// deadline/cancellation. It returns how many documents were inserted and a
+// map from a doc's index in docs to its individual error, if any.
+func (db *MongoDb) bulkInsertCtx(ctx context.Context, coll string, docs []interface{}, opts BulkOptions) (inserted int, failures map[int]error, err error) {
+	err = runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		bulk := sess.DB("").C(coll).Bulk()
+		if !opts.Ordered {
+			bulk.Unordered()
+		}
+		bulk.Insert(docs...)
+
+		_, runErr := bulk.Run()
+		failures = bulkErrorsByIndex(runErr)
+		if failures != nil {
+			inserted = len(docs) - len(failures)
+			if opts.Ordered {
+				// An ordered bulk stops at its first failure, so only the
+				// documents before it were written.
+				inserted = len(docs)
+				for idx := range failures {
+					if idx >= 0 && idx < inserted {
+						inserted = idx
+					}
+				}
+			}
+			return nil
+		}
+
+		if runErr != nil {
+			return runErr
+		}
+
+		inserted = len(docs)
+		return nil
+	})
+
+	return inserted, failures, err
+}
+
+// bulkUpsertCtx upserts (selector, update) pairs via the mgo Bulk API,
+// honoring ctx's deadline/cancellation.
+func (db *MongoDb) bulkUpsertCtx(ctx context.Context, coll string, pairs []interface{}, opts BulkOptions) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		bulk := sess.DB("").C(coll).Bulk()
+		if !opts.Ordered {
+			bulk.Unordered()
+		}
+		bulk.Upsert(pairs...)
+
+		_, err := bulk.Run()
+		return err
+	})
+}
@@ -0,0 +1,105 @@
+package mgoHelpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// fakeBulkWriter is a minimal BulkWriter test double that fails insertion of
+// whichever documents match a predicate, so insertPairsCtx's per-document
+// error reporting can be exercised without a live database.
+type fakeBulkWriter struct {
+	failIndexes map[int]error
+}
+
+func (f *fakeBulkWriter) BulkInsert(ctx context.Context, coll string, docs []interface{}, opts BulkOptions) (int, map[int]error, error) {
+	failures := make(map[int]error)
+	for i := range docs {
+		if err, ok := f.failIndexes[i]; ok {
+			failures[i] = err
+		}
+	}
+
+	return len(docs) - len(failures), failures, nil
+}
+
+func (f *fakeBulkWriter) BulkUpsert(ctx context.Context, coll string, pairs []interface{}, opts BulkOptions) error {
+	return nil
+}
+
+// fakeBulkStorage pairs a fakeBulkWriter with enough of Storage to satisfy
+// the interface; every method besides the embedded BulkWriter is unused by
+// these tests.
+type fakeBulkStorage struct {
+	Storage
+	*fakeBulkWriter
+}
+
+var errFactoryFailed = errors.New("factory failed")
+
+func newBulkTestCollection(bw *fakeBulkWriter) *MongoCollection {
+	c, err := NewMongoCollectionWithStorage(&fakeBulkStorage{fakeBulkWriter: bw}, "entries")
+	if err != nil {
+		panic(err)
+	}
+	c.SetFactoryFunc(func(_ *MongoCollection, param interface{}) MongoEntry {
+		return &numEntry{ID: bson.NewObjectId(), N: param.(int)}
+	})
+
+	return c
+}
+
+// TestCreateBulkRecordsPerDocumentInsertFailures asserts that a document
+// rejected by the Storage backend during CreateBulk no longer aborts the
+// whole batch: every other document's own pair still reports success.
+func TestCreateBulkRecordsPerDocumentInsertFailures(t *testing.T) {
+	c := newBulkTestCollection(&fakeBulkWriter{failIndexes: map[int]error{1: errFactoryFailed}})
+
+	result, err := c.CreateBulkCtx(context.Background(), []interface{}{1, 2, 3}, BulkOptions{Ordered: false})
+	if err != nil {
+		t.Fatalf("CreateBulkCtx: %v", err)
+	}
+
+	if result.Inserted != 2 {
+		t.Errorf("Inserted = %d, want 2", result.Inserted)
+	}
+	if len(result.Pairs) != 3 {
+		t.Fatalf("len(Pairs) = %d, want 3", len(result.Pairs))
+	}
+	if result.Pairs[1].Error != errFactoryFailed {
+		t.Errorf("Pairs[1].Error = %v, want errFactoryFailed", result.Pairs[1].Error)
+	}
+	if result.Pairs[0].Error != nil || result.Pairs[2].Error != nil {
+		t.Errorf("unrelated pairs were marked failed: %+v", result.Pairs)
+	}
+}
+
+// TestInsertBulkRecordsPerDocumentInsertFailures asserts a failure reported
+// by the Storage backend for one document in the batch is attached to that
+// document's pair without discarding the others.
+func TestInsertBulkRecordsPerDocumentInsertFailures(t *testing.T) {
+	c := newBulkTestCollection(&fakeBulkWriter{failIndexes: map[int]error{1: errFactoryFailed}})
+
+	entries := []MongoEntry{
+		&numEntry{ID: bson.NewObjectId(), N: 1},
+		&numEntry{ID: bson.NewObjectId(), N: 2},
+		&numEntry{ID: bson.NewObjectId(), N: 3},
+	}
+
+	result, err := c.InsertBulkCtx(context.Background(), entries, BulkOptions{Ordered: false})
+	if err != nil {
+		t.Fatalf("InsertBulkCtx: %v", err)
+	}
+	if result.Inserted != 2 {
+		t.Errorf("Inserted = %d, want 2", result.Inserted)
+	}
+	if result.Pairs[1].Error != errFactoryFailed {
+		t.Errorf("Pairs[1].Error = %v, want errFactoryFailed", result.Pairs[1].Error)
+	}
+	if result.Pairs[0].Error != nil || result.Pairs[2].Error != nil {
+		t.Errorf("unrelated pairs were marked failed: %+v", result.Pairs)
+	}
+}
@@ -1,6 +1,7 @@
 package mgoHelpers
 
 import (
+	"context"
 	"sync"
 
 	"gopkg.in/mgo.v2/bson"
@@ -54,13 +55,13 @@ func (c *MongoCollection) SetFactoryFunc(factoryFunc func(*MongoCollection, inte
 	c.factoryFunc = factoryFunc
 }
 
-func (c *MongoCollection) insertCore(entries []MongoEntry) error {
+func (c *MongoCollection) insertCoreCtx(ctx context.Context, entries []MongoEntry) error {
 	var inEntries = make([]interface{}, len(entries))
 	for i, v := range entries {
 		inEntries[i] = v
 	}
 
-	return c.Database.Insert(c.Collection, inEntries...)
+	return c.Database.InsertMany(ctx, c.Collection, inEntries)
 }
 
 func (c *MongoCollection) makeOne(factoryFuncParam interface{}) (entry MongoEntry, err error) {
@@ -72,7 +73,7 @@ func (c *MongoCollection) makeOne(factoryFuncParam interface{}) (entry MongoEntr
 	return entry, nil
 }
 
-func (c *MongoCollection) createCore(factoryFuncParamSet []interface{}) (entries []MongoEntry, err error) {
+func (c *MongoCollection) createCoreCtx(ctx context.Context, factoryFuncParamSet []interface{}) (entries []MongoEntry, err error) {
 	var entryerr EntryErrorPairs
 	for _, params := range factoryFuncParamSet {
 		entry, eErr := c.makeOne(params)
@@ -84,28 +85,27 @@ func (c *MongoCollection) createCore(factoryFuncParamSet []interface{}) (entries
 	}
 
 	entries = entryerr.MakeEntrySlice()
-	err = c.insertCore(entries)
+	err = c.insertCoreCtx(ctx, entries)
 
 	return entries, err
 }
 
-// Insert adds a ready-made entry into the database.
-func (c *MongoCollection) Insert(entry MongoEntry) (err error) {
-	c.MutexExec(func() { err = c.insertCore([]MongoEntry{entry}) })
+// InsertCtx adds a ready-made entry into the database, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) InsertCtx(ctx context.Context, entry MongoEntry) (err error) {
+	c.MutexExec(func() { err = c.insertCoreCtx(ctx, []MongoEntry{entry}) })
 
 	return err
 }
 
-func (c *MongoCollection) InsertBulk(entries []MongoEntry) (err error) {
-	c.MutexExec(func() { err = c.insertCore(entries) })
-
-	return err
+// Insert adds a ready-made entry into the database.
+func (c *MongoCollection) Insert(entry MongoEntry) (err error) {
+	return c.InsertCtx(context.Background(), entry)
 }
 
-// Create creates a new entry from specified param and factory function and inserts it into database. Please note that unless your factory depends on items in the collection you should run Insert instead.
-func (c *MongoCollection) Create(factoryFuncParams interface{}) (entry MongoEntry, err error) {
+// CreateCtx creates a new entry from specified param and factory function and inserts it into database, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) CreateCtx(ctx context.Context, factoryFuncParams interface{}) (entry MongoEntry, err error) {
 	var entries []MongoEntry
-	c.MutexExec(func() { entries, err = c.createCore([]interface{}{factoryFuncParams}) })
+	c.MutexExec(func() { entries, err = c.createCoreCtx(ctx, []interface{}{factoryFuncParams}) })
 
 	if err != nil {
 		return nil, err
@@ -114,38 +114,43 @@ func (c *MongoCollection) Create(factoryFuncParams interface{}) (entry MongoEntr
 	return entries[0], nil
 }
 
-func (c *MongoCollection) CreateBulk(factoryFuncParamSet []interface{}) (entries []MongoEntry, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			err = errPanic
-		}
-	}()
+// Create creates a new entry from specified param and factory function and inserts it into database. Please note that unless your factory depends on items in the collection you should run Insert instead.
+func (c *MongoCollection) Create(factoryFuncParams interface{}) (entry MongoEntry, err error) {
+	return c.CreateCtx(context.Background(), factoryFuncParams)
+}
 
-	c.MutexExec(func() { entries, err = c.createCore(factoryFuncParamSet) })
-	if err != nil {
-		return nil, err
+// ReadCtx returns the entry that matches specified entry ID, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) ReadCtx(ctx context.Context, entryID string, result MongoEntry) bool {
+	b, idParseErr := GetObjectIDFromString(entryID)
+	if idParseErr != nil {
+		return false
 	}
 
-	return entries, nil
+	return c.Database.FindOne(ctx, c.Collection, bson.M{"_id": b}, result)
 }
 
 // Read returns the entry that matches specified entry ID.
 func (c *MongoCollection) Read(entryID string, result MongoEntry) bool {
-	return c.Database.FindById(c.Collection, entryID, result)
+	return c.ReadCtx(context.Background(), entryID, result)
 }
 
-// ReadAll returns all entries in the database. The first argument *must* be a pointer to a slice.
-func (c *MongoCollection) ReadAll(result interface{}) (err error) {
+// ReadAllCtx returns all entries in the database, honoring ctx's deadline/cancellation. The first argument *must* be a pointer to a slice.
+func (c *MongoCollection) ReadAllCtx(ctx context.Context, result interface{}) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = errPanic
 		}
 	}()
-	return c.Database.FindAll(c.Collection, result)
+	return c.Database.Find(ctx, c.Collection, bson.M{}, result)
 }
 
-// Update modifies entry based on input parameters.
-func (c *MongoCollection) Update(entryID string, factoryFuncParam interface{}) (entry MongoEntry, status error) {
+// ReadAll returns all entries in the database. The first argument *must* be a pointer to a slice.
+func (c *MongoCollection) ReadAll(result interface{}) (err error) {
+	return c.ReadAllCtx(context.Background(), result)
+}
+
+// UpdateCtx modifies entry based on input parameters, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) UpdateCtx(ctx context.Context, entryID string, factoryFuncParam interface{}) (entry MongoEntry, status error) {
 	c.MutexExec(func() {
 		b, idParseErr := GetObjectIDFromString(entryID)
 
@@ -157,7 +162,12 @@ func (c *MongoCollection) Update(entryID string, factoryFuncParam interface{}) (
 		} else {
 			newEntry = c.factoryFunc(c, factoryFuncParam)
 			newEntry.SetBsonID(b)
-			err = c.Database.Update(c.Collection, b, newEntry)
+
+			var m bson.M
+			m, err = toBsonM(newEntry)
+			if err == nil {
+				err = c.Database.UpdateOne(ctx, c.Collection, b, m)
+			}
 		}
 		entry = newEntry
 		status = err
@@ -165,8 +175,13 @@ func (c *MongoCollection) Update(entryID string, factoryFuncParam interface{}) (
 	return entry, status
 }
 
-// Delete removes entry from the database.
-func (c *MongoCollection) Delete(entryID string) (status error) {
+// Update modifies entry based on input parameters.
+func (c *MongoCollection) Update(entryID string, factoryFuncParam interface{}) (entry MongoEntry, status error) {
+	return c.UpdateCtx(context.Background(), entryID, factoryFuncParam)
+}
+
+// DeleteCtx removes entry from the database, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) DeleteCtx(ctx context.Context, entryID string) (status error) {
 	c.MutexExec(func() {
 		var err error
 		b, idParseErr := GetObjectIDFromString(entryID)
@@ -174,7 +189,7 @@ func (c *MongoCollection) Delete(entryID string) (status error) {
 		if idParseErr != nil {
 			err = idParseErr
 		} else {
-			err = c.Database.Remove(c.Collection, b)
+			err = c.Database.DeleteOne(ctx, c.Collection, b)
 		}
 		status = err
 	})
@@ -182,18 +197,59 @@ func (c *MongoCollection) Delete(entryID string) (status error) {
 	return status
 }
 
-// DeleteAll removes all entries from the collection.
-func (c *MongoCollection) DeleteAll() (status error) {
-	c.MutexExec(func() { status = c.Database.RemoveAll(c.Collection) })
+// Delete removes entry from the database.
+func (c *MongoCollection) Delete(entryID string) (status error) {
+	return c.DeleteCtx(context.Background(), entryID)
+}
+
+// DeleteAllCtx removes all entries from the collection, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) DeleteAllCtx(ctx context.Context) (status error) {
+	c.MutexExec(func() {
+		_, status = c.Database.DeleteMany(ctx, c.Collection, bson.M{})
+	})
 
 	return status
 }
 
-// NewMongoCollection creates a new instance of the MongoDB collection.
+// DeleteAll removes all entries from the collection.
+func (c *MongoCollection) DeleteAll() (status error) {
+	return c.DeleteAllCtx(context.Background())
+}
+
+// NewMongoCollection creates a new instance of the MongoDB collection backed
+// by dbInstance, the gopkg.in/mgo.v2-based Storage this package has always
+// used. Use NewMongoCollectionWithOptions to declare indexes via
+// WithIndex/WithUniqueIndex/WithTTLIndex at construction time.
 func NewMongoCollection(dbInstance *MongoDb, collName string) *MongoCollection {
 	c := MongoCollection{}
-	c.Database = dbInstance
+	c.Database = NewMgoStorage(dbInstance)
 	c.Collection = collName
 
 	return &c
 }
+
+// NewMongoCollectionWithOptions creates a new instance of the MongoDB
+// collection backed by dbInstance. Pass WithIndex/WithUniqueIndex/
+// WithTTLIndex to declare indexes that should be created (or verified) once,
+// here, instead of on every connect.
+func NewMongoCollectionWithOptions(dbInstance *MongoDb, collName string, opts ...CollectionOption) (*MongoCollection, error) {
+	return NewMongoCollectionWithStorage(NewMgoStorage(dbInstance), collName, opts...)
+}
+
+// NewMongoCollectionWithStorage creates a new instance of the MongoDB
+// collection backed by an arbitrary Storage implementation, letting wrappers
+// migrate off mgo.v2 (see mongoDriverDb) or substitute an in-memory Storage
+// in tests without changing how the collection itself is used.
+func NewMongoCollectionWithStorage(storage Storage, collName string, opts ...CollectionOption) (*MongoCollection, error) {
+	c := MongoCollection{}
+	c.Database = storage
+	c.Collection = collName
+
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &c, nil
+}
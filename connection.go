@@ -0,0 +1,144 @@
+package mgoHelpers
+
+import (
+	"context"
+	"time"
+)
+
+// ConnectOptions configures ConnectWithOptions beyond what Connect offers: a
+// bounded session pool, retrying dials, and a background health monitor that
+// transparently redials on failure.
+type ConnectOptions struct {
+	// PoolLimit caps the number of sockets mgo keeps open per server.
+	PoolLimit int
+	// MaxIdleTime is applied as the session's socket timeout (mgo's
+	// SetSocketTimeout): a socket that does not respond for this long is
+	// closed. Sockets are not closed for sitting idle in the pool. Zero
+	// leaves mgo's default in place.
+	MaxIdleTime time.Duration
+	// RetryAttempts is how many additional times to retry a failed dial.
+	RetryAttempts int
+	// RetryBackoff is the delay between dial retries.
+	RetryBackoff time.Duration
+	// PingInterval is how often the background monitor pings the primary and
+	// redials if unreachable. Zero disables the monitor.
+	PingInterval time.Duration
+}
+
+// Health reports the current state of a MongoDb's connection, for use in readiness probes.
+type Health struct {
+	Connected     bool
+	LastError     error
+	LastCheckedAt time.Time
+}
+
+// ConnectWithOptions dials dsn, retrying up to opts.RetryAttempts times with
+// opts.RetryBackoff between attempts, applies opts.PoolLimit/MaxIdleTime to
+// the resulting session, and, if opts.PingInterval is set, starts a
+// background goroutine that pings the primary on that interval and
+// transparently redials on failure.
+func (db *MongoDb) ConnectWithOptions(ctx context.Context, dsn string, opts ConnectOptions) error {
+	if err := db.dialWithRetry(ctx, dsn, opts); err != nil {
+		return err
+	}
+
+	sess := db.session()
+	if opts.PoolLimit > 0 {
+		sess.SetPoolLimit(opts.PoolLimit)
+	}
+	if opts.MaxIdleTime > 0 {
+		sess.SetSocketTimeout(opts.MaxIdleTime)
+	}
+
+	if opts.PingInterval > 0 {
+		db.startMonitor(dsn, opts)
+	}
+
+	return nil
+}
+
+func (db *MongoDb) dialWithRetry(ctx context.Context, dsn string, opts ConnectOptions) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= opts.RetryAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(opts.RetryBackoff):
+			}
+		}
+
+		if lastErr = db.ConnectCtx(ctx, dsn); lastErr == nil {
+			return nil
+		}
+	}
+
+	return lastErr
+}
+
+// startMonitor replaces any previously running monitor with one that pings
+// the primary every opts.PingInterval and redials via dialWithRetry on
+// failure, until Disconnect cancels it.
+func (db *MongoDb) startMonitor(dsn string, opts ConnectOptions) {
+	db.mu.Lock()
+	if db.monitorCancel != nil {
+		db.monitorCancel()
+	}
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	db.monitorCancel = cancel
+	db.mu.Unlock()
+
+	go db.monitorLoop(monitorCtx, dsn, opts)
+}
+
+func (db *MongoDb) monitorLoop(ctx context.Context, dsn string, opts ConnectOptions) {
+	ticker := time.NewTicker(opts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			db.checkHealth(ctx, dsn, opts)
+		}
+	}
+}
+
+func (db *MongoDb) checkHealth(ctx context.Context, dsn string, opts ConnectOptions) {
+	sess := db.session()
+
+	pingErr := errNotConnected
+	if sess != nil {
+		pingErr = sess.Ping()
+	}
+
+	db.mu.Lock()
+	db.health = Health{Connected: pingErr == nil, LastError: pingErr, LastCheckedAt: time.Now()}
+	db.mu.Unlock()
+
+	if pingErr == nil {
+		return
+	}
+
+	if err := db.dialWithRetry(ctx, dsn, opts); err != nil {
+		db.mu.Lock()
+		db.health.LastError = err
+		db.mu.Unlock()
+	}
+}
+
+// IsConnected reports whether the most recent health check (or connection
+// attempt, if no monitor is running via ConnectWithOptions) found the
+// primary reachable.
+func (db *MongoDb) IsConnected() bool {
+	return db.Health().Connected
+}
+
+// Health returns a snapshot of the connection's current state, for readiness probes.
+func (db *MongoDb) Health() Health {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.health
+}
@@ -0,0 +1,107 @@
+package mgoHelpers
+
+import (
+	"context"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// IndexOptions configures an index created via EnsureIndex.
+type IndexOptions struct {
+	Unique      bool
+	Sparse      bool
+	Background  bool
+	Name        string
+	ExpireAfter time.Duration
+}
+
+func (o IndexOptions) toMgoIndex(keys []string) mgo.Index {
+	return mgo.Index{
+		Key:         keys,
+		Unique:      o.Unique,
+		Sparse:      o.Sparse,
+		Background:  o.Background,
+		Name:        o.Name,
+		ExpireAfter: o.ExpireAfter,
+	}
+}
+
+// IndexInfo describes an existing index, as reported by Indexes.
+type IndexInfo struct {
+	Name   string
+	Keys   []string
+	Unique bool
+}
+
+// EnsureIndex creates the index described by keys and opts if it does not
+// already exist. It fails with errStorageUnsupported if the collection's
+// Storage backend does not implement Indexer.
+func (c *MongoCollection) EnsureIndex(keys []string, opts IndexOptions) (err error) {
+	indexer, ok := c.Database.(Indexer)
+	if !ok {
+		return errStorageUnsupported
+	}
+
+	c.MutexExec(func() { err = indexer.EnsureIndex(context.Background(), c.Collection, keys, opts) })
+
+	return err
+}
+
+// EnsureUniqueIndex creates a unique index on keys if it does not already exist.
+func (c *MongoCollection) EnsureUniqueIndex(keys []string) error {
+	return c.EnsureIndex(keys, IndexOptions{Unique: true})
+}
+
+// EnsureTTLIndex creates an index on field that expires documents expireAfter past their indexed timestamp.
+func (c *MongoCollection) EnsureTTLIndex(field string, expireAfter time.Duration) error {
+	return c.EnsureIndex([]string{field}, IndexOptions{ExpireAfter: expireAfter})
+}
+
+// DropIndex removes the named index from the collection. It fails with
+// errStorageUnsupported if the collection's Storage backend does not
+// implement Indexer.
+func (c *MongoCollection) DropIndex(name string) (err error) {
+	indexer, ok := c.Database.(Indexer)
+	if !ok {
+		return errStorageUnsupported
+	}
+
+	c.MutexExec(func() { err = indexer.DropIndex(context.Background(), c.Collection, name) })
+
+	return err
+}
+
+// Indexes lists the indexes currently defined on the collection. It fails
+// with errStorageUnsupported if the collection's Storage backend does not
+// implement Indexer.
+func (c *MongoCollection) Indexes() (result []IndexInfo, err error) {
+	indexer, ok := c.Database.(Indexer)
+	if !ok {
+		return nil, errStorageUnsupported
+	}
+
+	c.MutexExec(func() { result, err = indexer.Indexes(context.Background(), c.Collection) })
+
+	return result, err
+}
+
+// CollectionOption configures a MongoCollection at construction time, see NewMongoCollectionWithOptions.
+type CollectionOption func(*MongoCollection) error
+
+// WithIndex declares an index that NewMongoCollectionWithOptions creates (or verifies) once the collection is built.
+func WithIndex(keys []string, opts IndexOptions) CollectionOption {
+	return func(c *MongoCollection) error {
+		return c.EnsureIndex(keys, opts)
+	}
+}
+
+// WithUniqueIndex declares a unique index that NewMongoCollectionWithOptions creates (or verifies) once the collection is built.
+func WithUniqueIndex(keys []string) CollectionOption {
+	return WithIndex(keys, IndexOptions{Unique: true})
+}
+
+// WithTTLIndex declares a TTL index that NewMongoCollectionWithOptions creates (or verifies) once the collection is built.
+func WithTTLIndex(field string, expireAfter time.Duration) CollectionOption {
+	return WithIndex([]string{field}, IndexOptions{ExpireAfter: expireAfter})
+}
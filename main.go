@@ -1,7 +1,9 @@
 package mgoHelpers
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"gopkg.in/mgo.v2"
@@ -9,89 +11,250 @@ import (
 )
 
 const (
-	mongoConnectionTimeout = 5 * time.Second
+	mongoConnectionTimeout  = 5 * time.Second
+	defaultOperationTimeout = 5 * time.Second
 )
 
 var errNoFactoryFunc = errors.New("No factory function")
 var errBulkOpAborted = errors.New("Bulk operation aborted")
 var errPanic = errors.New("Procedure panic")
+var errNotConnected = errors.New("Not connected to MongoDB")
 
 type MongoDb struct {
-	sess *mgo.Session
+	mu            sync.Mutex
+	sess          *mgo.Session
+	monitorCancel context.CancelFunc
+	health        Health
 }
 
-func (db *MongoDb) Connect(dsn string) error {
-	var err error
+// session returns the current mgo.Session, if any, guarding against a
+// concurrent redial started by the health monitor (see ConnectWithOptions).
+func (db *MongoDb) session() *mgo.Session {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.sess
+}
+
+// setSession installs sess as the current session, timestamps a fresh
+// Health snapshot off of it, and closes whichever session it replaces (e.g.
+// the stale one left behind by a monitor-triggered redial in
+// ConnectWithOptions) so its sockets and cluster-monitor goroutine don't leak.
+func (db *MongoDb) setSession(sess *mgo.Session) {
+	db.mu.Lock()
+	old := db.sess
+	db.sess = sess
+	db.health = Health{Connected: sess != nil, LastCheckedAt: time.Now()}
+	db.mu.Unlock()
+
+	if old != nil && old != sess {
+		old.Close()
+	}
+}
+
+// ensureContext returns ctx unchanged if it already carries a deadline,
+// otherwise it wraps it with defaultOperationTimeout. A nil ctx is treated
+// as context.Background(). The returned cancel func must always be called.
+func ensureContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, defaultOperationTimeout)
+}
+
+// runWithContext runs fn on a separate goroutine and returns as soon as fn
+// completes or ctx is done, whichever comes first. mgo.v2 has no native
+// context support, so cancellation only stops the caller from waiting;
+// the underlying socket operation is left to run to completion or to its
+// own mgo timeout.
+func runWithContext(ctx context.Context, fn func() error) error {
+	ctx, cancel := ensureContext(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- fn() }()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
 
-	db.sess, err = mgo.DialWithTimeout(dsn, mongoConnectionTimeout)
+// ConnectCtx dials dsn honoring ctx's deadline/cancellation instead of the
+// fixed mongoConnectionTimeout.
+func (db *MongoDb) ConnectCtx(ctx context.Context, dsn string) error {
+	ctx, cancel := ensureContext(ctx)
+	defer cancel()
 
-	return err
+	timeout := mongoConnectionTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	return runWithContext(ctx, func() error {
+		sess, err := mgo.DialWithTimeout(dsn, timeout)
+		if err != nil {
+			return err
+		}
+		db.setSession(sess)
+		return nil
+	})
+}
+
+// Connect dials dsn with the default operation timeout. Use ConnectCtx to
+// control the deadline explicitly, or ConnectWithOptions for retries, pooling
+// and a background health monitor.
+func (db *MongoDb) Connect(dsn string) error {
+	return db.ConnectCtx(context.Background(), dsn)
 }
+
+// Disconnect closes the connection and stops the health monitor, if one is
+// running. It is idempotent and safe to call on a MongoDb that was never
+// connected.
 func (db *MongoDb) Disconnect() {
-	db.sess.Close()
+	db.mu.Lock()
+	sess := db.sess
+	db.sess = nil
+	cancel := db.monitorCancel
+	db.monitorCancel = nil
+	db.health = Health{}
+	db.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	if sess != nil {
+		sess.Close()
+	}
+}
+
+func (db *MongoDb) InsertCtx(ctx context.Context, coll string, v ...interface{}) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		return sess.DB("").C(coll).Insert(v...)
+	})
 }
 
 func (db *MongoDb) Insert(coll string, v ...interface{}) error {
-	sess := db.sess.Copy()
-	defer sess.Close()
+	return db.InsertCtx(context.Background(), coll, v...)
+}
+
+func (db *MongoDb) FindCtx(ctx context.Context, coll string, query map[string]interface{}, v interface{}) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		bsonQuery := bson.M{}
 
-	return sess.DB("").C(coll).Insert(v...)
+		for k, qv := range query {
+			bsonQuery[k] = qv
+		}
+
+		return sess.DB("").C(coll).Find(bsonQuery).All(v)
+	})
 }
 
 func (db *MongoDb) Find(coll string, query map[string]interface{}, v interface{}) error {
-	sess := db.sess.Copy()
-	defer sess.Close()
+	return db.FindCtx(context.Background(), coll, query, v)
+}
 
-	bsonQuery := bson.M{}
+func (db *MongoDb) FindByIdCtx(ctx context.Context, coll string, id string, v interface{}) bool {
+	var found bool
+	runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
 
-	for k, qv := range query {
-		bsonQuery[k] = qv
-	}
+		found = mgo.ErrNotFound != sess.DB("").C(coll).FindId(id).One(v)
+		return nil
+	})
 
-	return sess.DB("").C(coll).Find(bsonQuery).All(v)
+	return found
 }
 
 func (db *MongoDb) FindById(coll string, id string, v interface{}) bool {
-	sess := db.sess.Copy()
-	defer sess.Close()
+	return db.FindByIdCtx(context.Background(), coll, id, v)
+}
 
-	return mgo.ErrNotFound != sess.DB("").C(coll).FindId(id).One(v)
+func (db *MongoDb) FindAllCtx(ctx context.Context, coll string, v interface{}) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		return sess.DB("").C(coll).Find(bson.M{}).All(v)
+	})
 }
 
 func (db *MongoDb) FindAll(coll string, v interface{}) error {
-	sess := db.sess.Copy()
-	defer sess.Close()
+	return db.FindAllCtx(context.Background(), coll, v)
+}
 
-	return sess.DB("").C(coll).Find(bson.M{}).All(v)
+func (db *MongoDb) UpdateCtx(ctx context.Context, coll string, id interface{}, v interface{}) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		return sess.DB("").C(coll).Update(bson.M{"_id": id}, bson.M{"$set": v})
+	})
 }
 
 func (db *MongoDb) Update(coll string, id interface{}, v interface{}) error {
-	sess := db.sess.Copy()
-	defer sess.Close()
+	return db.UpdateCtx(context.Background(), coll, id, v)
+}
 
-	return sess.DB("").C(coll).Update(bson.M{"_id": id}, bson.M{"$set": v})
+func (db *MongoDb) RemoveCtx(ctx context.Context, coll string, id interface{}) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		_, err := sess.DB("").C(coll).RemoveAll(bson.M{"_id": id})
+
+		return err
+	})
 }
 
 func (db *MongoDb) Remove(coll string, id interface{}) error {
-	sess := db.sess.Copy()
-	defer sess.Close()
+	return db.RemoveCtx(context.Background(), coll, id)
+}
+
+func (db *MongoDb) RemoveAllCtx(ctx context.Context, coll string) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
 
-	_, err := sess.DB("").C(coll).RemoveAll(bson.M{"_id": id})
+		_, err := sess.DB("").C(coll).RemoveAll(bson.M{})
 
-	return err
+		return err
+	})
 }
 
 func (db *MongoDb) RemoveAll(coll string) error {
-	sess := db.sess.Copy()
-	defer sess.Close()
+	return db.RemoveAllCtx(context.Background(), coll)
+}
+
+// AggregateCtx runs an aggregation pipeline against coll and decodes every
+// resulting document into result, honoring ctx's deadline/cancellation.
+func (db *MongoDb) AggregateCtx(ctx context.Context, coll string, pipeline []bson.M, result interface{}) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
 
-	_, err := sess.DB("").C(coll).RemoveAll(bson.M{})
+		stages := make([]interface{}, len(pipeline))
+		for i, stage := range pipeline {
+			stages[i] = stage
+		}
 
-	return err
+		return sess.DB("").C(coll).Pipe(stages).All(result)
+	})
 }
 
 func (db *MongoDb) SessExec(cb func(*mgo.Session)) {
-	var sess = db.sess.Copy()
+	var sess = db.session().Copy()
 	defer sess.Close()
 
 	cb(sess)
@@ -108,7 +271,7 @@ func (e *DbEntryBase) BsonID() bson.ObjectId { return e.ID }
 func (e *DbEntryBase) SetBsonID(id bson.ObjectId) { e.ID = id }
 
 type MongoStorageInfo struct {
-	Database   *MongoDb
+	Database   Storage
 	Collection string
 }
 
@@ -0,0 +1,80 @@
+package mgoHelpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEnsureContext(t *testing.T) {
+	cases := []struct {
+		name        string
+		ctx         context.Context
+		wantDefault bool // whether the returned ctx should carry defaultOperationTimeout
+	}{
+		{name: "nil treated as background", ctx: nil, wantDefault: true},
+		{name: "background gets default deadline", ctx: context.Background(), wantDefault: true},
+		{name: "existing deadline is preserved", ctx: func() context.Context {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+			return ctx
+		}(), wantDefault: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, cancel := ensureContext(tc.ctx)
+			defer cancel()
+
+			deadline, ok := got.Deadline()
+			if !ok {
+				t.Fatalf("ensureContext(%v) returned a context with no deadline", tc.ctx)
+			}
+
+			untilDeadline := time.Until(deadline)
+			if tc.wantDefault {
+				if untilDeadline <= 0 || untilDeadline > defaultOperationTimeout {
+					t.Errorf("deadline %v from now, want within (0, %v]", untilDeadline, defaultOperationTimeout)
+				}
+			} else if untilDeadline <= defaultOperationTimeout {
+				t.Errorf("deadline %v from now, want longer than defaultOperationTimeout (%v); ensureContext overrode the caller's deadline", untilDeadline, defaultOperationTimeout)
+			}
+		})
+	}
+}
+
+func TestRunWithContextReturnsFnResult(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	if err := runWithContext(context.Background(), func() error { return nil }); err != nil {
+		t.Errorf("runWithContext with a succeeding fn = %v, want nil", err)
+	}
+
+	if err := runWithContext(context.Background(), func() error { return wantErr }); err != wantErr {
+		t.Errorf("runWithContext with a failing fn = %v, want %v", err, wantErr)
+	}
+}
+
+func TestRunWithContextReturnsPromptlyOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fnDone := make(chan struct{})
+	start := time.Now()
+
+	err := runWithContext(ctx, func() error {
+		defer close(fnDone)
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("runWithContext took %v to return after an already-canceled ctx, want near-instant", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+
+	<-fnDone // fn keeps running in the background; drain it so the test doesn't leak a goroutine.
+}
@@ -0,0 +1,256 @@
+package mgoHelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var errResultNotSlicePointer = errors.New("mgoHelpers: result must be a pointer to a slice")
+
+// MemoryStorage is an in-process Storage implementation backed by plain Go
+// slices, meant for unit-testing factory functions and MongoCollection
+// wrappers without a live database. It is not a production backend: query
+// matching only supports exact-value equality on top-level fields, there is
+// no notion of indexes, and Aggregate is not supported.
+type MemoryStorage struct {
+	mu   sync.Mutex
+	docs map[string][]bson.M
+}
+
+// NewMemoryStorage creates an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{docs: make(map[string][]bson.M)}
+}
+
+// lessValue orders a and b for sorting. Numeric values (of any width/kind)
+// are compared numerically rather than lexicographically so that, e.g., 9
+// sorts before 10; any other pair of values falls back to comparing their
+// string representations.
+func lessValue(a, b interface{}) bool {
+	an, aOk := toFloat64(a)
+	bn, bOk := toFloat64(b)
+	if aOk && bOk {
+		return an < bn
+	}
+
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func memoryMatches(doc bson.M, query bson.M) bool {
+	for k, v := range query {
+		if doc[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// decodeBsonMInto decodes docs into result, a pointer to a slice, via a
+// bson marshal/unmarshal round-trip through each document's concrete type.
+func decodeBsonMInto(docs []bson.M, result interface{}) error {
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return errResultNotSlicePointer
+	}
+
+	sliceVal := rv.Elem()
+	elemType := sliceVal.Type().Elem()
+	out := reflect.MakeSlice(sliceVal.Type(), 0, len(docs))
+
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		elemPtr := reflect.New(elemType)
+		if err := bson.Unmarshal(data, elemPtr.Interface()); err != nil {
+			return err
+		}
+
+		out = reflect.Append(out, elemPtr.Elem())
+	}
+
+	sliceVal.Set(out)
+
+	return nil
+}
+
+func (s *MemoryStorage) InsertOne(ctx context.Context, coll string, doc interface{}) error {
+	return s.InsertMany(ctx, coll, []interface{}{doc})
+}
+
+func (s *MemoryStorage) InsertMany(ctx context.Context, coll string, docs []interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, d := range docs {
+		m, err := toBsonM(d)
+		if err != nil {
+			return err
+		}
+		s.docs[coll] = append(s.docs[coll], m)
+	}
+
+	return nil
+}
+
+func (s *MemoryStorage) UpdateOne(ctx context.Context, coll string, id interface{}, update bson.M) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range s.docs[coll] {
+		if doc["_id"] == id {
+			for k, v := range update {
+				doc[k] = v
+			}
+			return nil
+		}
+	}
+
+	return mgo.ErrNotFound
+}
+
+func (s *MemoryStorage) UpdateMany(ctx context.Context, coll string, query bson.M, update bson.M) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range s.docs[coll] {
+		if memoryMatches(doc, query) {
+			for k, v := range update {
+				doc[k] = v
+			}
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (s *MemoryStorage) FindOne(ctx context.Context, coll string, query bson.M, result interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range s.docs[coll] {
+		if memoryMatches(doc, query) {
+			data, err := bson.Marshal(doc)
+			return err == nil && bson.Unmarshal(data, result) == nil
+		}
+	}
+
+	return false
+}
+
+func (s *MemoryStorage) Find(ctx context.Context, coll string, query bson.M, result interface{}, opts ...FindOption) error {
+	s.mu.Lock()
+	var matched []bson.M
+	for _, doc := range s.docs[coll] {
+		if memoryMatches(doc, query) {
+			matched = append(matched, doc)
+		}
+	}
+	s.mu.Unlock()
+
+	o := buildFindOptions(opts)
+	// Stable sorts run from the last field to the first, so the first field
+	// ends up as the primary key, as in mgo.
+	for k := len(o.sort) - 1; k >= 0; k-- {
+		field := o.sort[k]
+		sort.SliceStable(matched, func(i, j int) bool {
+			key, desc := field, false
+			if len(key) > 0 && key[0] == '-' {
+				key, desc = key[1:], true
+			}
+
+			if desc {
+				return lessValue(matched[j][key], matched[i][key])
+			}
+			return lessValue(matched[i][key], matched[j][key])
+		})
+	}
+
+	if o.skip > 0 && o.skip < len(matched) {
+		matched = matched[o.skip:]
+	} else if o.skip >= len(matched) {
+		matched = nil
+	}
+	if o.limit > 0 && o.limit < len(matched) {
+		matched = matched[:o.limit]
+	}
+
+	return decodeBsonMInto(matched, result)
+}
+
+func (s *MemoryStorage) DeleteOne(ctx context.Context, coll string, id interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	docs := s.docs[coll]
+	for i, doc := range docs {
+		if doc["_id"] == id {
+			s.docs[coll] = append(docs[:i], docs[i+1:]...)
+			return nil
+		}
+	}
+
+	return mgo.ErrNotFound
+}
+
+func (s *MemoryStorage) DeleteMany(ctx context.Context, coll string, query bson.M) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []bson.M
+	for _, doc := range s.docs[coll] {
+		if memoryMatches(doc, query) {
+			n++
+			continue
+		}
+		kept = append(kept, doc)
+	}
+	s.docs[coll] = kept
+
+	return n, nil
+}
+
+func (s *MemoryStorage) Count(ctx context.Context, coll string, query bson.M) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range s.docs[coll] {
+		if memoryMatches(doc, query) {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (s *MemoryStorage) Aggregate(ctx context.Context, coll string, pipeline []bson.M, result interface{}) error {
+	return errStorageUnsupported
+}
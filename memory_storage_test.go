@@ -0,0 +1,83 @@
+package mgoHelpers
+
+import (
+	"context"
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+type numEntry struct {
+	ID bson.ObjectId `bson:"_id,omitempty"`
+	N  int           `bson:"n"`
+	S  string        `bson:"s"`
+}
+
+func (e *numEntry) BsonID() bson.ObjectId      { return e.ID }
+func (e *numEntry) SetBsonID(id bson.ObjectId) { e.ID = id }
+
+// TestMemoryStorageFindSortNumeric guards against sorting numeric fields as
+// strings, which would put 10 before 2.
+func TestMemoryStorageFindSortNumeric(t *testing.T) {
+	s := NewMemoryStorage()
+	ctx := context.Background()
+
+	for _, n := range []int{9, 10, 2} {
+		if err := s.InsertOne(ctx, "nums", &numEntry{ID: bson.NewObjectId(), N: n}); err != nil {
+			t.Fatalf("InsertOne(%d): %v", n, err)
+		}
+	}
+
+	var got []numEntry
+	if err := s.Find(ctx, "nums", bson.M{}, &got, Sort("n")); err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+
+	want := []int{2, 9, 10}
+	if len(got) != len(want) {
+		t.Fatalf("got %d docs, want %d", len(got), len(want))
+	}
+	for i, n := range want {
+		if got[i].N != n {
+			t.Errorf("got[%d].N = %d, want %d (full order: %v)", i, got[i].N, n, got)
+		}
+	}
+}
+
+// TestMemoryStorageUpdatePartial asserts UpdateOne/UpdateMany only touch the
+// fields present in the update document, matching the $set semantics every
+// other Storage backend is expected to provide.
+func TestMemoryStorageUpdatePartial(t *testing.T) {
+	s := NewMemoryStorage()
+	ctx := context.Background()
+
+	id := bson.NewObjectId()
+	if err := s.InsertOne(ctx, "nums", &numEntry{ID: id, N: 1, S: "keep-me"}); err != nil {
+		t.Fatalf("InsertOne: %v", err)
+	}
+
+	if err := s.UpdateOne(ctx, "nums", id, bson.M{"n": 2}); err != nil {
+		t.Fatalf("UpdateOne: %v", err)
+	}
+
+	var got numEntry
+	if !s.FindOne(ctx, "nums", bson.M{"_id": id}, &got) {
+		t.Fatalf("FindOne: not found")
+	}
+	if got.N != 2 || got.S != "keep-me" {
+		t.Errorf("UpdateOne touched unrelated field: got %+v, want N=2 S=keep-me", got)
+	}
+
+	n, err := s.UpdateMany(ctx, "nums", bson.M{"_id": id}, bson.M{"n": 3})
+	if err != nil || n != 1 {
+		t.Fatalf("UpdateMany: n=%d err=%v", n, err)
+	}
+
+	got = numEntry{}
+	if !s.FindOne(ctx, "nums", bson.M{"_id": id}, &got) {
+		t.Fatalf("FindOne: not found")
+	}
+	if got.N != 3 || got.S != "keep-me" {
+		t.Errorf("UpdateMany touched unrelated field: got %+v, want N=3 S=keep-me", got)
+	}
+}
@@ -0,0 +1,199 @@
+package mgoHelpers
+
+import (
+	"context"
+
+	mgobson "gopkg.in/mgo.v2/bson"
+
+	driverbson "go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoDriverDb is a Storage implementation backed by
+// go.mongodb.org/mongo-driver, the maintained successor to the unmaintained
+// gopkg.in/mgo.v2. It lets MongoCollection wrappers migrate off mgo.v2
+// without rewriting the wrapper itself: only the Storage passed to
+// NewMongoCollectionWithStorage changes.
+type mongoDriverDb struct {
+	client *mongo.Client
+	dbName string
+}
+
+// NewMongoDriverStorage adapts an already-connected mongo-driver Client to
+// the Storage interface.
+func NewMongoDriverStorage(client *mongo.Client, dbName string) Storage {
+	return &mongoDriverDb{client: client, dbName: dbName}
+}
+
+func (d *mongoDriverDb) coll(name string) *mongo.Collection {
+	return d.client.Database(d.dbName).Collection(name)
+}
+
+func (d *mongoDriverDb) InsertOne(ctx context.Context, coll string, doc interface{}) error {
+	_, err := d.coll(coll).InsertOne(ctx, doc)
+	return err
+}
+
+func (d *mongoDriverDb) InsertMany(ctx context.Context, coll string, docs []interface{}) error {
+	_, err := d.coll(coll).InsertMany(ctx, docs)
+	return err
+}
+
+func (d *mongoDriverDb) UpdateOne(ctx context.Context, coll string, id interface{}, update mgobson.M) error {
+	_, err := d.coll(coll).UpdateOne(ctx, driverbson.M{"_id": id}, driverbson.M{"$set": driverbson.M(update)})
+	return err
+}
+
+func (d *mongoDriverDb) UpdateMany(ctx context.Context, coll string, query mgobson.M, update mgobson.M) (int, error) {
+	res, err := d.coll(coll).UpdateMany(ctx, driverbson.M(query), driverbson.M{"$set": driverbson.M(update)})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(res.ModifiedCount), nil
+}
+
+func (d *mongoDriverDb) FindOne(ctx context.Context, coll string, query mgobson.M, result interface{}) bool {
+	return d.coll(coll).FindOne(ctx, driverbson.M(query)).Decode(result) == nil
+}
+
+func (d *mongoDriverDb) Find(ctx context.Context, coll string, query mgobson.M, result interface{}, opts ...FindOption) error {
+	o := buildFindOptions(opts)
+
+	findOpts := options.Find()
+	if o.limit > 0 {
+		findOpts.SetLimit(int64(o.limit))
+	}
+	if o.skip > 0 {
+		findOpts.SetSkip(int64(o.skip))
+	}
+	if len(o.sort) > 0 {
+		sortDoc := driverbson.D{}
+		for _, field := range o.sort {
+			key, dir := field, 1
+			if len(key) > 0 && key[0] == '-' {
+				key, dir = key[1:], -1
+			}
+			sortDoc = append(sortDoc, driverbson.E{Key: key, Value: dir})
+		}
+		findOpts.SetSort(sortDoc)
+	}
+
+	cur, err := d.coll(coll).Find(ctx, driverbson.M(query), findOpts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return cur.All(ctx, result)
+}
+
+func (d *mongoDriverDb) DeleteOne(ctx context.Context, coll string, id interface{}) error {
+	_, err := d.coll(coll).DeleteOne(ctx, driverbson.M{"_id": id})
+	return err
+}
+
+func (d *mongoDriverDb) DeleteMany(ctx context.Context, coll string, query mgobson.M) (int, error) {
+	res, err := d.coll(coll).DeleteMany(ctx, driverbson.M(query))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(res.DeletedCount), nil
+}
+
+func (d *mongoDriverDb) Count(ctx context.Context, coll string, query mgobson.M) (int, error) {
+	n, err := d.coll(coll).CountDocuments(ctx, driverbson.M(query))
+	return int(n), err
+}
+
+func (d *mongoDriverDb) Aggregate(ctx context.Context, coll string, pipeline []mgobson.M, result interface{}) error {
+	stages := make(driverbson.A, len(pipeline))
+	for i, stage := range pipeline {
+		stages[i] = driverbson.M(stage)
+	}
+
+	cur, err := d.coll(coll).Aggregate(ctx, stages)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	return cur.All(ctx, result)
+}
+
+// changeStreamDoc mirrors the subset of a MongoDB change event document
+// Watch cares about.
+type changeStreamDoc struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID interface{} `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument driverbson.M `bson:"fullDocument"`
+}
+
+func changeEventType(operationType string) ChangeEventType {
+	switch operationType {
+	case "insert":
+		return ChangeInsert
+	case "update":
+		return ChangeUpdate
+	case "delete":
+		return ChangeDelete
+	case "replace":
+		return ChangeReplace
+	default:
+		return ChangeEventType(operationType)
+	}
+}
+
+// Watch satisfies Watcher, delivering RawChangeEvents off a mongo-driver
+// change stream until ctx is done.
+func (d *mongoDriverDb) Watch(ctx context.Context, coll string, pipeline []mgobson.M, opts WatchOptions) (<-chan RawChangeEvent, error) {
+	stages := make(driverbson.A, len(pipeline))
+	for i, stage := range pipeline {
+		stages[i] = driverbson.M(stage)
+	}
+
+	csOpts := options.ChangeStream()
+	if opts.FullDocument {
+		csOpts.SetFullDocument(options.UpdateLookup)
+	}
+
+	cs, err := d.coll(coll).Watch(ctx, stages, csOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan RawChangeEvent)
+	go func() {
+		defer close(events)
+		// Close with a fresh context: ctx is typically already done by the
+		// time we get here (that's what stopped cs.Next below), and closing
+		// with a canceled context makes the driver skip the killCursors
+		// round-trip, leaking the server-side cursor until it idles out.
+		defer cs.Close(context.Background())
+
+		for cs.Next(ctx) {
+			var doc changeStreamDoc
+			if err := cs.Decode(&doc); err != nil {
+				continue
+			}
+
+			event := RawChangeEvent{
+				Type:       changeEventType(doc.OperationType),
+				DocumentID: doc.DocumentKey.ID,
+				Document:   mgobson.M(doc.FullDocument),
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
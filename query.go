@@ -0,0 +1,247 @@
+package mgoHelpers
+
+import (
+	"context"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// FindOptions controls pagination and ordering for MongoCollection.Find.
+type FindOptions struct {
+	limit int
+	skip  int
+	sort  []string
+}
+
+// FindOption configures a FindOptions value. Use Limit, Skip and Sort to
+// build up the set of options passed to Find/FindCtx.
+type FindOption func(*FindOptions)
+
+// Limit caps the number of returned documents.
+func Limit(n int) FindOption {
+	return func(o *FindOptions) { o.limit = n }
+}
+
+// Skip skips the first n matching documents, for use alongside Limit to paginate.
+func Skip(n int) FindOption {
+	return func(o *FindOptions) { o.skip = n }
+}
+
+// Sort orders results by the given fields, in mgo notation (prefix a field
+// with "-" for descending order).
+func Sort(fields ...string) FindOption {
+	return func(o *FindOptions) { o.sort = fields }
+}
+
+func buildFindOptions(opts []FindOption) FindOptions {
+	var o FindOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// FindQueryCtx runs query against coll, applying the given FindOptions, honoring ctx's deadline/cancellation.
+func (db *MongoDb) FindQueryCtx(ctx context.Context, coll string, query bson.M, v interface{}, opts ...FindOption) error {
+	o := buildFindOptions(opts)
+
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		q := sess.DB("").C(coll).Find(query)
+		if len(o.sort) > 0 {
+			q = q.Sort(o.sort...)
+		}
+		if o.skip > 0 {
+			q = q.Skip(o.skip)
+		}
+		if o.limit > 0 {
+			q = q.Limit(o.limit)
+		}
+
+		return q.All(v)
+	})
+}
+
+// FindQuery runs query against coll, applying the given FindOptions.
+func (db *MongoDb) FindQuery(coll string, query bson.M, v interface{}, opts ...FindOption) error {
+	return db.FindQueryCtx(context.Background(), coll, query, v, opts...)
+}
+
+// FindOneCtx runs query against coll and decodes the first match into v, honoring ctx's deadline/cancellation.
+func (db *MongoDb) FindOneCtx(ctx context.Context, coll string, query bson.M, v interface{}) bool {
+	var found bool
+	runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		found = mgo.ErrNotFound != sess.DB("").C(coll).Find(query).One(v)
+		return nil
+	})
+
+	return found
+}
+
+// FindOneQuery runs query against coll and decodes the first match into v.
+func (db *MongoDb) FindOneQuery(coll string, query bson.M, v interface{}) bool {
+	return db.FindOneCtx(context.Background(), coll, query, v)
+}
+
+// UpdateWhereCtx applies update to every document matching query and reports how many were touched, honoring ctx's deadline/cancellation.
+func (db *MongoDb) UpdateWhereCtx(ctx context.Context, coll string, query bson.M, update bson.M) (n int, err error) {
+	err = runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		info, uerr := sess.DB("").C(coll).UpdateAll(query, update)
+		if info != nil {
+			n = info.Updated
+		}
+		return uerr
+	})
+
+	return n, err
+}
+
+// UpdateWhere applies update to every document matching query and reports how many were touched.
+func (db *MongoDb) UpdateWhere(coll string, query bson.M, update bson.M) (int, error) {
+	return db.UpdateWhereCtx(context.Background(), coll, query, update)
+}
+
+// UpdateRawCtx replaces the document matching id with update as-is, without wrapping it in $set. It honors ctx's deadline/cancellation.
+func (db *MongoDb) UpdateRawCtx(ctx context.Context, coll string, id interface{}, update bson.M) error {
+	return runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		return sess.DB("").C(coll).Update(bson.M{"_id": id}, update)
+	})
+}
+
+// UpdateRaw replaces the document matching id with update as-is, without wrapping it in $set.
+func (db *MongoDb) UpdateRaw(coll string, id interface{}, update bson.M) error {
+	return db.UpdateRawCtx(context.Background(), coll, id, update)
+}
+
+// RemoveWhereCtx removes every document matching query and reports how many were removed, honoring ctx's deadline/cancellation.
+func (db *MongoDb) RemoveWhereCtx(ctx context.Context, coll string, query bson.M) (n int, err error) {
+	err = runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		info, rerr := sess.DB("").C(coll).RemoveAll(query)
+		if info != nil {
+			n = info.Removed
+		}
+		return rerr
+	})
+
+	return n, err
+}
+
+// RemoveWhere removes every document matching query and reports how many were removed.
+func (db *MongoDb) RemoveWhere(coll string, query bson.M) (int, error) {
+	return db.RemoveWhereCtx(context.Background(), coll, query)
+}
+
+// CountCtx reports how many documents in coll match query, honoring ctx's deadline/cancellation.
+func (db *MongoDb) CountCtx(ctx context.Context, coll string, query bson.M) (n int, err error) {
+	err = runWithContext(ctx, func() error {
+		sess := db.session().Copy()
+		defer sess.Close()
+
+		var cerr error
+		n, cerr = sess.DB("").C(coll).Find(query).Count()
+		return cerr
+	})
+
+	return n, err
+}
+
+// Count reports how many documents in coll match query.
+func (db *MongoDb) Count(coll string, query bson.M) (int, error) {
+	return db.CountCtx(context.Background(), coll, query)
+}
+
+// FindCtx returns every entry matching query, applying the given FindOptions, honoring ctx's deadline/cancellation. result must be a pointer to a slice.
+func (c *MongoCollection) FindCtx(ctx context.Context, query bson.M, result interface{}, opts ...FindOption) error {
+	return c.Database.Find(ctx, c.Collection, query, result, opts...)
+}
+
+// Find returns every entry matching query, applying the given FindOptions. result must be a pointer to a slice.
+func (c *MongoCollection) Find(query bson.M, result interface{}, opts ...FindOption) error {
+	return c.FindCtx(context.Background(), query, result, opts...)
+}
+
+// FindOneCtx decodes the first entry matching query into result, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) FindOneCtx(ctx context.Context, query bson.M, result MongoEntry) bool {
+	return c.Database.FindOne(ctx, c.Collection, query, result)
+}
+
+// FindOne decodes the first entry matching query into result.
+func (c *MongoCollection) FindOne(query bson.M, result MongoEntry) bool {
+	return c.FindOneCtx(context.Background(), query, result)
+}
+
+// UpdateWhereCtx applies update to every entry matching query and reports how many were touched, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) UpdateWhereCtx(ctx context.Context, query bson.M, update bson.M) (n int, err error) {
+	c.MutexExec(func() { n, err = c.Database.UpdateMany(ctx, c.Collection, query, update) })
+
+	return n, err
+}
+
+// UpdateWhere applies update to every entry matching query and reports how many were touched.
+func (c *MongoCollection) UpdateWhere(query bson.M, update bson.M) (int, error) {
+	return c.UpdateWhereCtx(context.Background(), query, update)
+}
+
+// DeleteWhereCtx removes every entry matching query and reports how many were removed, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) DeleteWhereCtx(ctx context.Context, query bson.M) (n int, err error) {
+	c.MutexExec(func() { n, err = c.Database.DeleteMany(ctx, c.Collection, query) })
+
+	return n, err
+}
+
+// DeleteWhere removes every entry matching query and reports how many were removed.
+func (c *MongoCollection) DeleteWhere(query bson.M) (int, error) {
+	return c.DeleteWhereCtx(context.Background(), query)
+}
+
+// CountCtx reports how many entries match query, honoring ctx's deadline/cancellation.
+func (c *MongoCollection) CountCtx(ctx context.Context, query bson.M) (int, error) {
+	return c.Database.Count(ctx, c.Collection, query)
+}
+
+// Count reports how many entries match query.
+func (c *MongoCollection) Count(query bson.M) (int, error) {
+	return c.CountCtx(context.Background(), query)
+}
+
+// PatchCtx applies a partial update to the entry matching entryID, honoring ctx's deadline/cancellation.
+// Unlike Update, patch is not run through a factory function: it is merged into
+// the document via a single $set, so callers can update a subset of fields
+// without reconstructing the whole entry.
+func (c *MongoCollection) PatchCtx(ctx context.Context, entryID string, patch bson.M) (status error) {
+	c.MutexExec(func() {
+		b, idParseErr := GetObjectIDFromString(entryID)
+
+		if idParseErr != nil {
+			status = idParseErr
+			return
+		}
+
+		status = c.Database.UpdateOne(ctx, c.Collection, b, patch)
+	})
+
+	return status
+}
+
+// Patch applies a partial update to the entry matching entryID.
+// Unlike Update, patch is not run through a factory function: it is merged into
+// the document via a single $set, so callers can update a subset of fields
+// without reconstructing the whole entry.
+func (c *MongoCollection) Patch(entryID string, patch bson.M) error {
+	return c.PatchCtx(context.Background(), entryID, patch)
+}
@@ -0,0 +1,158 @@
+package mgoHelpers
+
+import (
+	"context"
+	"errors"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var errStorageUnsupported = errors.New("Operation not supported by this Storage backend")
+
+// Storage is the persistence boundary MongoCollection is built on. It is
+// satisfied by the original gopkg.in/mgo.v2-based MongoDb (wrapped via
+// NewMgoStorage) and by mongoDriverDb, a go.mongodb.org/mongo-driver-backed
+// implementation, so wrappers can migrate off mgo.v2 without rewriting
+// their MongoCollection-based code. An in-memory Storage (see
+// NewMemoryStorage) is also available for unit-testing factory functions
+// without a live database.
+type Storage interface {
+	InsertOne(ctx context.Context, coll string, doc interface{}) error
+	InsertMany(ctx context.Context, coll string, docs []interface{}) error
+	UpdateOne(ctx context.Context, coll string, id interface{}, update bson.M) error
+	UpdateMany(ctx context.Context, coll string, query bson.M, update bson.M) (int, error)
+	FindOne(ctx context.Context, coll string, query bson.M, result interface{}) bool
+	Find(ctx context.Context, coll string, query bson.M, result interface{}, opts ...FindOption) error
+	DeleteOne(ctx context.Context, coll string, id interface{}) error
+	DeleteMany(ctx context.Context, coll string, query bson.M) (int, error)
+	Count(ctx context.Context, coll string, query bson.M) (int, error)
+	Aggregate(ctx context.Context, coll string, pipeline []bson.M, result interface{}) error
+}
+
+// Indexer is implemented by Storage backends that support index management.
+// MongoCollection's index methods type-assert for it and fail with
+// errStorageUnsupported against backends that don't.
+type Indexer interface {
+	EnsureIndex(ctx context.Context, coll string, keys []string, opts IndexOptions) error
+	DropIndex(ctx context.Context, coll string, name string) error
+	Indexes(ctx context.Context, coll string) ([]IndexInfo, error)
+}
+
+// BulkWriter is implemented by Storage backends that support bulk writes
+// with per-document error reporting. MongoCollection's bulk methods
+// type-assert for it and fail with errStorageUnsupported against backends
+// that don't.
+type BulkWriter interface {
+	BulkInsert(ctx context.Context, coll string, docs []interface{}, opts BulkOptions) (inserted int, failures map[int]error, err error)
+	BulkUpsert(ctx context.Context, coll string, pairs []interface{}, opts BulkOptions) error
+}
+
+// toBsonM marshals v, a MongoEntry or similar struct, to its bson.M
+// representation so it can be used as a $set document.
+func toBsonM(v interface{}) (bson.M, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m bson.M
+	err = bson.Unmarshal(data, &m)
+
+	return m, err
+}
+
+// mgoStorage adapts a *MongoDb, the gopkg.in/mgo.v2-based implementation
+// this package has used since its inception, to the Storage interface.
+type mgoStorage struct {
+	db *MongoDb
+}
+
+// NewMgoStorage adapts an already-connected MongoDb to the Storage
+// interface, for use with NewMongoCollectionWithStorage.
+func NewMgoStorage(db *MongoDb) Storage {
+	return &mgoStorage{db: db}
+}
+
+func (s *mgoStorage) InsertOne(ctx context.Context, coll string, doc interface{}) error {
+	return s.db.InsertCtx(ctx, coll, doc)
+}
+
+func (s *mgoStorage) InsertMany(ctx context.Context, coll string, docs []interface{}) error {
+	return s.db.InsertCtx(ctx, coll, docs...)
+}
+
+func (s *mgoStorage) UpdateOne(ctx context.Context, coll string, id interface{}, update bson.M) error {
+	return s.db.UpdateRawCtx(ctx, coll, id, bson.M{"$set": update})
+}
+
+func (s *mgoStorage) UpdateMany(ctx context.Context, coll string, query bson.M, update bson.M) (int, error) {
+	return s.db.UpdateWhereCtx(ctx, coll, query, bson.M{"$set": update})
+}
+
+func (s *mgoStorage) FindOne(ctx context.Context, coll string, query bson.M, result interface{}) bool {
+	return s.db.FindOneCtx(ctx, coll, query, result)
+}
+
+func (s *mgoStorage) Find(ctx context.Context, coll string, query bson.M, result interface{}, opts ...FindOption) error {
+	return s.db.FindQueryCtx(ctx, coll, query, result, opts...)
+}
+
+func (s *mgoStorage) DeleteOne(ctx context.Context, coll string, id interface{}) error {
+	return s.db.RemoveCtx(ctx, coll, id)
+}
+
+func (s *mgoStorage) DeleteMany(ctx context.Context, coll string, query bson.M) (int, error) {
+	return s.db.RemoveWhereCtx(ctx, coll, query)
+}
+
+func (s *mgoStorage) Count(ctx context.Context, coll string, query bson.M) (int, error) {
+	return s.db.CountCtx(ctx, coll, query)
+}
+
+func (s *mgoStorage) Aggregate(ctx context.Context, coll string, pipeline []bson.M, result interface{}) error {
+	return s.db.AggregateCtx(ctx, coll, pipeline, result)
+}
+
+func (s *mgoStorage) EnsureIndex(ctx context.Context, coll string, keys []string, opts IndexOptions) error {
+	var err error
+	s.db.SessExec(func(sess *mgo.Session) {
+		err = sess.DB("").C(coll).EnsureIndex(opts.toMgoIndex(keys))
+	})
+
+	return err
+}
+
+func (s *mgoStorage) DropIndex(ctx context.Context, coll string, name string) error {
+	var err error
+	s.db.SessExec(func(sess *mgo.Session) {
+		err = sess.DB("").C(coll).DropIndexName(name)
+	})
+
+	return err
+}
+
+func (s *mgoStorage) Indexes(ctx context.Context, coll string) (result []IndexInfo, err error) {
+	s.db.SessExec(func(sess *mgo.Session) {
+		indexes, ierr := sess.DB("").C(coll).Indexes()
+		if ierr != nil {
+			err = ierr
+			return
+		}
+
+		result = make([]IndexInfo, len(indexes))
+		for i, idx := range indexes {
+			result[i] = IndexInfo{Name: idx.Name, Keys: idx.Key, Unique: idx.Unique}
+		}
+	})
+
+	return result, err
+}
+
+func (s *mgoStorage) BulkInsert(ctx context.Context, coll string, docs []interface{}, opts BulkOptions) (int, map[int]error, error) {
+	return s.db.bulkInsertCtx(ctx, coll, docs, opts)
+}
+
+func (s *mgoStorage) BulkUpsert(ctx context.Context, coll string, pairs []interface{}, opts BulkOptions) error {
+	return s.db.bulkUpsertCtx(ctx, coll, pairs, opts)
+}
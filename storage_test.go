@@ -0,0 +1,56 @@
+package mgoHelpers
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// mgoTestDSN returns the DSN for a live mongod to run integration tests
+// against, skipping the calling test if none is configured. mgoStorage only
+// talks to mgo.v2 over a real socket, so its behavior can't be exercised
+// against a fake.
+func mgoTestDSN(t *testing.T) string {
+	dsn := os.Getenv("MGOHELPERS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MGOHELPERS_TEST_DSN not set; skipping test against a live mongod")
+	}
+	return dsn
+}
+
+// TestMgoStorageUpdateManyIsPartial guards against mgoStorage.UpdateMany
+// replacing whole documents instead of $set-patching them, which previously
+// made MongoCollection.UpdateWhere behave differently depending on backend.
+func TestMgoStorageUpdateManyIsPartial(t *testing.T) {
+	dsn := mgoTestDSN(t)
+
+	db := GetDb()
+	if err := db.Connect(dsn); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer db.Disconnect()
+
+	storage := NewMgoStorage(db)
+	ctx := context.Background()
+	coll := "mgohelpers_update_many_partial_test"
+	defer storage.DeleteMany(ctx, coll, bson.M{})
+
+	id := bson.NewObjectId()
+	if err := storage.InsertOne(ctx, coll, &numEntry{ID: id, N: 1, S: "keep-me"}); err != nil {
+		t.Fatalf("InsertOne: %v", err)
+	}
+
+	if _, err := storage.UpdateMany(ctx, coll, bson.M{"_id": id}, bson.M{"n": 2}); err != nil {
+		t.Fatalf("UpdateMany: %v", err)
+	}
+
+	var got numEntry
+	if !storage.FindOne(ctx, coll, bson.M{"_id": id}, &got) {
+		t.Fatalf("FindOne: not found")
+	}
+	if got.N != 2 || got.S != "keep-me" {
+		t.Errorf("UpdateMany did not patch in place: got %+v, want N=2 S=keep-me", got)
+	}
+}
@@ -0,0 +1,133 @@
+package mgoHelpers
+
+import (
+	"context"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AggregateCtx runs an aggregation pipeline against the collection and
+// decodes every resulting document into result, honoring ctx's
+// deadline/cancellation.
+func (c *MongoCollection) AggregateCtx(ctx context.Context, pipeline []bson.M, result interface{}) error {
+	return c.Database.Aggregate(ctx, c.Collection, pipeline, result)
+}
+
+// Aggregate runs an aggregation pipeline against the collection and decodes
+// every resulting document into result.
+func (c *MongoCollection) Aggregate(pipeline []bson.M, result interface{}) error {
+	return c.AggregateCtx(context.Background(), pipeline, result)
+}
+
+// ChangeEventType is the kind of change a ChangeEvent represents.
+type ChangeEventType string
+
+const (
+	ChangeInsert  ChangeEventType = "insert"
+	ChangeUpdate  ChangeEventType = "update"
+	ChangeDelete  ChangeEventType = "delete"
+	ChangeReplace ChangeEventType = "replace"
+)
+
+// ChangeEvent is one document delivered by MongoCollection.WatchCtx/Watch.
+type ChangeEvent struct {
+	Type       ChangeEventType
+	DocumentID interface{}
+	// Entry holds the affected document decoded via the collection's
+	// factoryFunc when the backend delivered a full document (inserts,
+	// replaces, and updates with FullDocument set) and a factory function is
+	// set on the collection; it is nil otherwise, and callers should fall
+	// back to Raw.
+	Entry MongoEntry
+	Raw   bson.M
+}
+
+// WatchOptions configures MongoCollection.WatchCtx/Watch.
+type WatchOptions struct {
+	// FullDocument, when true, requests the post-change document on update
+	// events too (the server's "updateLookup" option), not just on
+	// insert/replace events.
+	FullDocument bool
+}
+
+// RawChangeEvent is what a Watcher backend delivers, before
+// MongoCollection.WatchCtx decodes its document into the wrapper's entry
+// type via factoryFunc.
+type RawChangeEvent struct {
+	Type       ChangeEventType
+	DocumentID interface{}
+	Document   bson.M
+}
+
+// Watcher is implemented by Storage backends that support change streams.
+// MongoCollection.WatchCtx type-asserts for it and fails with
+// errStorageUnsupported against backends that don't; mgo.v2 predates change
+// streams and so never implements it.
+type Watcher interface {
+	Watch(ctx context.Context, coll string, pipeline []bson.M, opts WatchOptions) (<-chan RawChangeEvent, error)
+}
+
+// WatchCtx subscribes to a change stream over the collection. The stream
+// runs until ctx is done, at which point the returned channel is closed; a
+// caller that wants to stop watching should derive ctx from
+// context.WithCancel and call the cancel func. It fails with
+// errStorageUnsupported if the collection's Storage backend does not
+// implement Watcher.
+func (c *MongoCollection) WatchCtx(ctx context.Context, pipeline []bson.M, opts WatchOptions) (<-chan ChangeEvent, error) {
+	watcher, ok := c.Database.(Watcher)
+	if !ok {
+		return nil, errStorageUnsupported
+	}
+
+	raw, err := watcher.Watch(ctx, c.Collection, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ChangeEvent)
+	go func() {
+		defer close(events)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case rawEvent, ok := <-raw:
+				if !ok {
+					return
+				}
+
+				event := ChangeEvent{Type: rawEvent.Type, DocumentID: rawEvent.DocumentID, Raw: rawEvent.Document}
+				if c.factoryFunc != nil && rawEvent.Document != nil {
+					if entry, mkErr := c.makeOne(rawEvent.Document); mkErr == nil {
+						event.Entry = entry
+					}
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Watch subscribes to a change stream over the collection, returning a
+// cancel func that stops the stream and releases its underlying cursor; it
+// must be called once the caller is done watching. Use WatchCtx directly to
+// tie the subscription to an existing context instead.
+func (c *MongoCollection) Watch(pipeline []bson.M, opts WatchOptions) (<-chan ChangeEvent, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := c.WatchCtx(ctx, pipeline, opts)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return events, cancel, nil
+}